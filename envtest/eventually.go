@@ -35,13 +35,39 @@ import (
 // function (eventually after 20% of the wait time) to aid in debugging.
 func Eventually(t TestingT, condition func() (success bool, reason string), waitFor time.Duration, tick time.Duration, msgAndArgs ...interface{}) {
 	t.Helper()
+	eventually(t, func() (bool, string, string) {
+		ok, reason := condition()
+		return ok, reason, ""
+	}, waitFor, tick, msgAndArgs...)
+}
+
+// eventually is the shared implementation behind Eventually and EventuallyCondition.
+// It additionally threads a resourceVersion through to the WaitRecorder (if any)
+// attached to t via WithRecorder, which Eventually itself has no use for but
+// EventuallyCondition populates from the object it fetches on every poll.
+func eventually(t TestingT, condition func() (ok bool, reason string, resourceVersion string), waitFor time.Duration, tick time.Duration, msgAndArgs ...interface{}) {
+	t.Helper()
 
+	recorder := recorderFor(t)
 	var last string
 	start := time.Now()
 	require.Eventually(t, func() bool {
 		t.Helper()
 
-		ok, msg := condition()
+		ok, msg, rv := condition()
+		recorder.record(msg, rv)
+		// Only stamp an outcome on success: on timeout, require.Eventually calls
+		// t.FailNow() itself after this closure has returned false for the last
+		// time, which runs runtime.Goexit() in this goroutine and skips any code
+		// we'd otherwise run afterwards here, including a "recorder.finish"
+		// call. Stamping "timeout" here instead, on every failing poll, would
+		// mark the reason as concluded the moment it's superseded by the next
+		// one, even though the wait might go on to succeed. The still-pending
+		// last transition is instead marked "timeout" by WithRecorder's
+		// cleanup, via finishPending, if the test actually ends up failing.
+		if ok {
+			recorder.finish("success")
+		}
 		if time.Since(start) > waitFor/5 {
 			if !ok && msg != "" && msg != last {
 				last = msg
@@ -121,21 +147,36 @@ func (c *ConditionEvaluator) WithReason(reason string) *ConditionEvaluator {
 	return c
 }
 
-// EventuallyCondition asserts that the object returned by getter() eventually has a condition that matches the evaluator.
-func EventuallyCondition(t TestingT, getter func() (conditions.Getter, error), evaluator *ConditionEvaluator, msgAndArgs ...interface{}) {
+// EventuallyCondition asserts that the object returned by getter() eventually
+// satisfies matcher. matcher is typically a *ConditionEvaluator built with Is/IsNot,
+// but may be any Matcher, including combinators built with All, Any, and Not.
+func EventuallyCondition(t TestingT, getter func() (conditions.Getter, error), matcher Matcher, msgAndArgs ...interface{}) {
 	t.Helper()
-	Eventually(t, func() (bool, string) {
-		obj, err := getter()
-		require.NoError(t, err, "Error fetching object")
-		condition, descriptor, done := evaluator.matches(obj)
-		var reason string
-		if !done {
-			if condition != nil {
-				reason = fmt.Sprintf("Not done waiting for object %s: %s: %s", descriptor, condition.Reason, condition.Message)
-			} else {
-				reason = fmt.Sprintf("Not done waiting for object %s: no condition present", descriptor)
-			}
-		}
-		return done, reason
+	eventually(t, func() (bool, string, string) {
+		return matchCondition(t, getter, matcher)
 	}, wait.ForeverTestTimeout, 100*time.Millisecond, msgAndArgs...)
 }
+
+// matchCondition fetches the object via getter and evaluates matcher against it,
+// shaping the result into the (ok, reason, resourceVersion) triple that both
+// eventually and pollWithStrategy expect. It is shared by EventuallyCondition and
+// its EventuallyConditionWithStrategy/EventuallyConditionContext counterparts.
+func matchCondition(t TestingT, getter func() (conditions.Getter, error), matcher Matcher) (bool, string, string) {
+	t.Helper()
+	obj, err := getter()
+	require.NoError(t, err, "Error fetching object")
+
+	var resourceVersion string
+	if accessor, ok := obj.(interface{ GetResourceVersion() string }); ok {
+		resourceVersion = accessor.GetResourceVersion()
+	}
+
+	ok, descriptor, detail := matcher.Matches(obj)
+	if ok {
+		return true, descriptor, resourceVersion
+	}
+	if detail != "" {
+		return false, fmt.Sprintf("Not done waiting for object %s: %s", descriptor, detail), resourceVersion
+	}
+	return false, fmt.Sprintf("Not done waiting for object %s", descriptor), resourceVersion
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envtest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEventuallyOnlyStampsAnOutcomeOnTheTrulyFinalTransition(t *testing.T) {
+	var recorder WaitRecorder
+	WithRecorder(t, &recorder)
+
+	attempt := 0
+	Eventually(t, func() (bool, string) {
+		attempt++
+		switch attempt {
+		case 1:
+			return false, "not ready yet"
+		case 2:
+			return false, "still waiting"
+		default:
+			return true, "ready"
+		}
+	}, time.Second, time.Millisecond)
+
+	if len(recorder.Transitions) != 3 {
+		t.Fatalf("expected 3 distinct transitions, got %d: %+v", len(recorder.Transitions), recorder.Transitions)
+	}
+	for i, transition := range recorder.Transitions[:2] {
+		if transition.Outcome != "" {
+			t.Fatalf("expected transition %d to have no outcome stamped while the wait was still ongoing, got %q", i, transition.Outcome)
+		}
+	}
+	if recorder.Transitions[2].Outcome != "success" {
+		t.Fatalf("expected the final transition to be marked success, got %q", recorder.Transitions[2].Outcome)
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envtest
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// toUnstructured converts obj into an *unstructured.Unstructured so that it can be
+// inspected by status.Compute, which only understands the unstructured
+// representation of a Kubernetes object.
+func toUnstructured(obj client.Object) (*unstructured.Unstructured, error) {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		return u, nil
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert object to unstructured: %w", err)
+	}
+	return &unstructured.Unstructured{Object: content}, nil
+}
+
+// EventuallyCurrent asserts that the object returned by getter() eventually computes
+// to status.CurrentStatus according to sigs.k8s.io/cli-utils' kstatus package. Unlike
+// EventuallyCondition, this does not require the object to implement the KCP
+// third_party conditions.Getter interface, so it also works for stock Kubernetes
+// resources (Deployments, Pods, Services, ...) and for kcp objects such as
+// Workspaces or APIBindings that follow upstream kstatus conventions instead of
+// exposing a conditions.Getter. As with Eventually, the InProgress/Failed reason and
+// message reported by kstatus are logged once 20% of the wait time has elapsed.
+func EventuallyCurrent(t TestingT, getter func() (client.Object, error), msgAndArgs ...interface{}) {
+	t.Helper()
+	Eventually(t, func() (bool, string) {
+		obj, err := getter()
+		require.NoError(t, err, "Error fetching object")
+		u, err := toUnstructured(obj)
+		require.NoError(t, err, "Error converting object to unstructured")
+		result, err := status.Compute(u)
+		require.NoError(t, err, "Error computing status")
+		if result.Status == status.CurrentStatus {
+			return true, ""
+		}
+		return false, fmt.Sprintf("%s %s/%s is %s: %s", u.GetKind(), u.GetNamespace(), u.GetName(), result.Status, result.Message)
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, msgAndArgs...)
+}
+
+// EventuallyCurrentN asserts that every object returned by getters eventually
+// computes to status.CurrentStatus, aggregating all of them into a single wait. This
+// lets a test wait for an entire workload spread across multiple objects, possibly
+// fetched from different clusters, to converge in one call. As with Eventually, the
+// per-object status breakdown of the objects not yet Current is logged once 20% of
+// the wait time has elapsed, whenever it changes; the failure message on timeout
+// itself only states how many objects were waited on.
+func EventuallyCurrentN(t TestingT, getters ...func() (client.Object, error)) {
+	t.Helper()
+	Eventually(t, func() (bool, string) {
+		var notCurrent []string
+		for i, getter := range getters {
+			obj, err := getter()
+			require.NoError(t, err, "Error fetching object %d", i)
+			u, err := toUnstructured(obj)
+			require.NoError(t, err, "Error converting object %d to unstructured", i)
+			result, err := status.Compute(u)
+			require.NoError(t, err, "Error computing status for object %d", i)
+			if result.Status != status.CurrentStatus {
+				notCurrent = append(notCurrent, fmt.Sprintf("%s %s/%s: %s: %s", u.GetKind(), u.GetNamespace(), u.GetName(), result.Status, result.Message))
+			}
+		}
+		if len(notCurrent) == 0 {
+			return true, ""
+		}
+		return false, fmt.Sprintf("%d/%d objects not yet Current:\n  %s", len(notCurrent), len(getters), strings.Join(notCurrent, "\n  "))
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, fmt.Sprintf("waiting for %d objects to become Current", len(getters)))
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envtest
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// widget builds a generic CRD-shaped unstructured object with the observedGeneration
+// and condition kstatus uses to compute Current/InProgress/Failed for resources that
+// don't have a well-known, built-in status shape.
+func widget(name string, conditions ...map[string]interface{}) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("example.com/v1")
+	u.SetKind("Widget")
+	u.SetName(name)
+	u.SetNamespace("default")
+	u.SetGeneration(1)
+	status := map[string]interface{}{
+		"observedGeneration": int64(1),
+	}
+	if len(conditions) > 0 {
+		items := make([]interface{}, len(conditions))
+		for i, c := range conditions {
+			items[i] = c
+		}
+		status["conditions"] = items
+	}
+	u.Object["status"] = status
+	return u
+}
+
+func readyCondition(s string) map[string]interface{} {
+	return map[string]interface{}{"type": "Ready", "status": s}
+}
+
+func stalledCondition(s string) map[string]interface{} {
+	return map[string]interface{}{"type": "Stalled", "status": s}
+}
+
+func TestStatusComputeConventionsUsedByEventuallyCurrent(t *testing.T) {
+	cases := []struct {
+		name string
+		obj  *unstructured.Unstructured
+		want status.Status
+	}{
+		{"ready", widget("current", readyCondition("True")), status.CurrentStatus},
+		{"not yet ready", widget("in-progress", readyCondition("False")), status.InProgressStatus},
+		{"stalled", widget("failed", stalledCondition("True")), status.FailedStatus},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			result, err := status.Compute(c.obj)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Status != c.want {
+				t.Fatalf("expected %s, got %s: %s", c.want, result.Status, result.Message)
+			}
+		})
+	}
+}
+
+func TestToUnstructuredPassesThroughAnAlreadyUnstructuredObject(t *testing.T) {
+	u := widget("passthrough")
+	got, err := toUnstructured(u)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != u {
+		t.Fatalf("expected toUnstructured to return the same object rather than copy it")
+	}
+}
+
+func TestEventuallyCurrentSucceedsOnceTheObjectBecomesCurrent(t *testing.T) {
+	attempt := 0
+	EventuallyCurrent(t, func() (client.Object, error) {
+		attempt++
+		if attempt < 2 {
+			return widget("widget", readyCondition("False")), nil
+		}
+		return widget("widget", readyCondition("True")), nil
+	})
+	if attempt < 2 {
+		t.Fatalf("expected EventuallyCurrent to poll more than once before succeeding")
+	}
+}
+
+func TestEventuallyCurrentNSucceedsOnceEveryObjectIsCurrent(t *testing.T) {
+	EventuallyCurrentN(t,
+		func() (client.Object, error) { return widget("a", readyCondition("True")), nil },
+		func() (client.Object, error) { return widget("b", readyCondition("True")), nil },
+	)
+}
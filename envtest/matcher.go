@@ -0,0 +1,161 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envtest
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+// Matcher is satisfied by anything that can evaluate whether a conditions.Getter
+// currently matches some expectation. ConditionEvaluator implements Matcher, and
+// matchers can be combined with All, Any, and Not to express richer expectations
+// than a single condition type/status/reason check.
+type Matcher interface {
+	// Matches reports whether object satisfies the matcher. ok is the result,
+	// descriptor is a human-readable, stable description of what was being checked
+	// (used regardless of outcome), and detail carries additional context that is
+	// only meaningful when ok is false.
+	Matches(object conditions.Getter) (ok bool, descriptor string, detail string)
+}
+
+// matcherFunc adapts a plain function to the Matcher interface.
+type matcherFunc func(object conditions.Getter) (ok bool, descriptor string, detail string)
+
+func (f matcherFunc) Matches(object conditions.Getter) (bool, string, string) {
+	return f(object)
+}
+
+// Matches implements Matcher.
+func (c *ConditionEvaluator) Matches(object conditions.Getter) (bool, string, string) {
+	condition, descriptor, ok := c.matches(object)
+	if ok {
+		return true, descriptor, ""
+	}
+	if condition != nil {
+		return false, descriptor, fmt.Sprintf("%s: %s", condition.Reason, condition.Message)
+	}
+	return false, descriptor, "no condition present"
+}
+
+type allMatcher struct {
+	matchers []Matcher
+}
+
+// All returns a Matcher that matches if every one of matchers matches.
+func All(matchers ...Matcher) Matcher {
+	return &allMatcher{matchers: matchers}
+}
+
+func (a *allMatcher) Matches(object conditions.Getter) (bool, string, string) {
+	descriptors := make([]string, 0, len(a.matchers))
+	ok, detail := true, ""
+	for _, m := range a.matchers {
+		matched, descriptor, matcherDetail := m.Matches(object)
+		descriptors = append(descriptors, descriptor)
+		if ok && !matched {
+			ok, detail = false, matcherDetail
+		}
+	}
+	return ok, strings.Join(descriptors, " and "), detail
+}
+
+type anyMatcher struct {
+	matchers []Matcher
+}
+
+// Any returns a Matcher that matches if at least one of matchers matches.
+func Any(matchers ...Matcher) Matcher {
+	return &anyMatcher{matchers: matchers}
+}
+
+func (a *anyMatcher) Matches(object conditions.Getter) (bool, string, string) {
+	descriptors := make([]string, 0, len(a.matchers))
+	var ok bool
+	var lastDetail string
+	for _, m := range a.matchers {
+		matched, descriptor, detail := m.Matches(object)
+		descriptors = append(descriptors, descriptor)
+		if matched {
+			ok = true
+		} else {
+			lastDetail = detail
+		}
+	}
+	if ok {
+		return true, strings.Join(descriptors, " or "), ""
+	}
+	return false, strings.Join(descriptors, " or "), lastDetail
+}
+
+type notMatcher struct {
+	matcher Matcher
+}
+
+// Not returns a Matcher that matches if matcher does not.
+func Not(matcher Matcher) Matcher {
+	return &notMatcher{matcher: matcher}
+}
+
+func (n *notMatcher) Matches(object conditions.Getter) (bool, string, string) {
+	ok, descriptor, _ := n.matcher.Matches(object)
+	return !ok, fmt.Sprintf("not %s", descriptor), ""
+}
+
+// HasMessageContaining returns a Matcher that matches if conditionType is present on
+// the object and its message contains substr.
+func HasMessageContaining(conditionType conditionsv1alpha1.ConditionType, substr string) Matcher {
+	return matcherFunc(func(object conditions.Getter) (bool, string, string) {
+		descriptor := fmt.Sprintf("%s message to contain %q", conditionType, substr)
+		condition := conditions.Get(object, conditionType)
+		if condition == nil {
+			return false, descriptor, "no condition present"
+		}
+		if !strings.Contains(condition.Message, substr) {
+			return false, descriptor, fmt.Sprintf("message was %q", condition.Message)
+		}
+		return true, descriptor, ""
+	})
+}
+
+// HasObservedGeneration returns a Matcher that matches if every condition reported
+// by the object's conditions.Getter has an ObservedGeneration matching the object's
+// current metadata.generation, i.e. all conditions reflect the latest spec rather
+// than a stale observation. Conditions whose ObservedGeneration is unset (zero) are
+// not checked. This is useful for multicluster reconcilers that flip several
+// conditions in sequence after every spec change.
+func HasObservedGeneration() Matcher {
+	return matcherFunc(func(object conditions.Getter) (bool, string, string) {
+		descriptor := "all conditions to have observed the current generation"
+		accessor, ok := object.(metav1.Object)
+		if !ok {
+			return false, descriptor, fmt.Sprintf("%T does not expose a generation", object)
+		}
+		generation := accessor.GetGeneration()
+		for _, condition := range object.GetConditions() {
+			if condition.ObservedGeneration != 0 && condition.ObservedGeneration != generation {
+				return false, descriptor, fmt.Sprintf("%s observed generation %d, current is %d", condition.Type, condition.ObservedGeneration, generation)
+			}
+		}
+		return true, descriptor, ""
+	})
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envtest
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+)
+
+// fakeGetter is a minimal conditions.Getter used to exercise Matcher implementations
+// without a real kcp or Kubernetes object.
+type fakeGetter struct {
+	metav1.ObjectMeta
+	conditions conditionsv1alpha1.Conditions
+}
+
+func (f *fakeGetter) GetConditions() conditionsv1alpha1.Conditions { return f.conditions }
+func (f *fakeGetter) SetConditions(c conditionsv1alpha1.Conditions) { f.conditions = c }
+
+const readyType = conditionsv1alpha1.ConditionType("Ready")
+const reconcilingType = conditionsv1alpha1.ConditionType("Reconciling")
+
+func TestAllMatchesOnlyWhenEveryMatcherMatches(t *testing.T) {
+	obj := &fakeGetter{conditions: conditionsv1alpha1.Conditions{
+		{Type: readyType, Status: corev1.ConditionTrue},
+	}}
+
+	ok, _, _ := All(Is(readyType, corev1.ConditionTrue)).Matches(obj)
+	if !ok {
+		t.Fatalf("expected All to match when its sole matcher matches")
+	}
+
+	ok, _, _ = All(Is(readyType, corev1.ConditionTrue), Is(reconcilingType, corev1.ConditionTrue)).Matches(obj)
+	if ok {
+		t.Fatalf("expected All not to match when one matcher doesn't")
+	}
+}
+
+func TestAnyMatchesWhenAtLeastOneMatcherMatches(t *testing.T) {
+	obj := &fakeGetter{conditions: conditionsv1alpha1.Conditions{
+		{Type: readyType, Status: corev1.ConditionTrue},
+	}}
+
+	ok, _, _ := Any(Is(reconcilingType, corev1.ConditionTrue), Is(readyType, corev1.ConditionTrue)).Matches(obj)
+	if !ok {
+		t.Fatalf("expected Any to match when one of its matchers matches")
+	}
+
+	ok, _, _ = Any(Is(reconcilingType, corev1.ConditionTrue)).Matches(obj)
+	if ok {
+		t.Fatalf("expected Any not to match when none of its matchers do")
+	}
+}
+
+func TestNotInvertsTheUnderlyingMatcher(t *testing.T) {
+	obj := &fakeGetter{conditions: conditionsv1alpha1.Conditions{
+		{Type: reconcilingType, Status: corev1.ConditionTrue},
+	}}
+
+	ok, _, _ := Not(Is(reconcilingType, corev1.ConditionTrue)).Matches(obj)
+	if ok {
+		t.Fatalf("expected Not to invert a matching condition into a non-match")
+	}
+}
+
+func TestAllAndNotCombineLikeTheRequestExample(t *testing.T) {
+	obj := &fakeGetter{conditions: conditionsv1alpha1.Conditions{
+		{Type: readyType, Status: corev1.ConditionTrue, ObservedGeneration: 2},
+	}}
+	obj.Generation = 2
+
+	matcher := All(Is(readyType, corev1.ConditionTrue), Not(Is(reconcilingType, corev1.ConditionTrue)), HasObservedGeneration())
+	ok, descriptor, _ := matcher.Matches(obj)
+	if !ok {
+		t.Fatalf("expected the combined matcher to match, descriptor: %s", descriptor)
+	}
+}
+
+func TestHasMessageContaining(t *testing.T) {
+	obj := &fakeGetter{conditions: conditionsv1alpha1.Conditions{
+		{Type: readyType, Status: corev1.ConditionFalse, Message: "waiting for backend to provision"},
+	}}
+
+	ok, _, detail := HasMessageContaining(readyType, "provision").Matches(obj)
+	if !ok {
+		t.Fatalf("expected message match, detail: %s", detail)
+	}
+
+	ok, _, _ = HasMessageContaining(readyType, "nope").Matches(obj)
+	if ok {
+		t.Fatalf("expected no match for a substring that isn't present")
+	}
+}
+
+func TestHasObservedGenerationAcrossAllConditions(t *testing.T) {
+	obj := &fakeGetter{conditions: conditionsv1alpha1.Conditions{
+		{Type: readyType, ObservedGeneration: 1},
+		{Type: reconcilingType, ObservedGeneration: 2},
+	}}
+	obj.Generation = 2
+
+	ok, _, detail := HasObservedGeneration().Matches(obj)
+	if ok {
+		t.Fatalf("expected a stale condition to fail the match")
+	}
+	if !strings.Contains(detail, "Ready") {
+		t.Fatalf("expected detail to name the stale condition type, got: %s", detail)
+	}
+}
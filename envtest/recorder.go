@@ -0,0 +1,156 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envtest
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Transition is a single observed state change during a wait driven by
+// Eventually/EventuallyCondition.
+type Transition struct {
+	Time            time.Time `json:"time"`
+	Reason          string    `json:"reason"`
+	ResourceVersion string    `json:"resourceVersion,omitempty"`
+	// Outcome is empty while the wait is still ongoing, and set to "success" or
+	// "timeout" on the final transition once the wait concludes.
+	Outcome string `json:"outcome,omitempty"`
+}
+
+// WaitRecorder captures every distinct reason string seen during one or more waits,
+// together with enough metadata to reconstruct a timeline after the fact. Attach one
+// to a test with WithRecorder; without one, Eventually/EventuallyCondition record
+// nothing and behave exactly as before.
+type WaitRecorder struct {
+	// ArtifactPath, if set, is where Dump writes the timeline as JSON for
+	// post-mortem tooling and CI dashboards, in addition to logging it.
+	ArtifactPath string
+
+	mu          sync.Mutex
+	Transitions []Transition
+}
+
+var recorders sync.Map // map[TestingT]*WaitRecorder
+
+// WithRecorder attaches recorder to t so that subsequent Eventually/EventuallyCondition
+// calls made with t record their state transitions into it. If t is a *testing.T (or
+// otherwise implements testing.TB), the recorder's timeline is automatically dumped
+// when the test fails.
+func WithRecorder(t TestingT, recorder *WaitRecorder) {
+	recorders.Store(t, recorder)
+	if tb, ok := t.(testing.TB); ok {
+		tb.Cleanup(func() {
+			if tb.Failed() {
+				recorder.finishPending("timeout")
+				recorder.Dump(t)
+			}
+			recorders.Delete(t)
+		})
+	}
+}
+
+func recorderFor(t TestingT) *WaitRecorder {
+	v, ok := recorders.Load(t)
+	if !ok {
+		return nil
+	}
+	return v.(*WaitRecorder)
+}
+
+// record appends a transition if reason differs from the last recorded one. It is a
+// no-op on a nil *WaitRecorder, so call sites don't need to check for one first.
+func (r *WaitRecorder) record(reason, resourceVersion string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if n := len(r.Transitions); n > 0 && r.Transitions[n-1].Reason == reason {
+		return
+	}
+	r.Transitions = append(r.Transitions, Transition{Time: time.Now(), Reason: reason, ResourceVersion: resourceVersion})
+}
+
+// finish marks the final transition with outcome, if there is one.
+func (r *WaitRecorder) finish(outcome string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if n := len(r.Transitions); n > 0 {
+		r.Transitions[n-1].Outcome = outcome
+	}
+}
+
+// finishPending marks the final transition with outcome, but only if it doesn't
+// already have one. This is used as a fallback for waits like eventually, which
+// can't call finish itself on a timeout: require.Eventually calls t.FailNow() from
+// inside its own poll loop, which runs runtime.Goexit() and skips any code the
+// caller would otherwise run after it returns. Because a transition already
+// concluded with "success" is left untouched, this is safe to call once at test
+// end even when several waits shared the same recorder and only the last of them
+// failed.
+func (r *WaitRecorder) finishPending(outcome string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if n := len(r.Transitions); n > 0 && r.Transitions[n-1].Outcome == "" {
+		r.Transitions[n-1].Outcome = outcome
+	}
+}
+
+// Dump logs a compact, one-line-per-transition timeline to t, and, if ArtifactPath is
+// set, writes the same timeline as JSON.
+func (r *WaitRecorder) Dump(t TestingT) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, transition := range r.Transitions {
+		if transition.Outcome != "" {
+			t.Logf("[%s] %s (%s)", transition.Time.Format(time.RFC3339Nano), transition.Reason, transition.Outcome)
+		} else {
+			t.Logf("[%s] %s", transition.Time.Format(time.RFC3339Nano), transition.Reason)
+		}
+	}
+
+	if r.ArtifactPath == "" {
+		return
+	}
+	data, err := json.MarshalIndent(r.Transitions, "", "  ")
+	if err != nil {
+		t.Logf("Error marshalling wait timeline: %v", err)
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(r.ArtifactPath), 0o755); err != nil {
+		t.Logf("Error creating directory for wait timeline: %v", err)
+		return
+	}
+	if err := os.WriteFile(r.ArtifactPath, data, 0o644); err != nil {
+		t.Logf("Error writing wait timeline: %v", err)
+	}
+}
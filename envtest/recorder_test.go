@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envtest
+
+import "testing"
+
+func TestWaitRecorderRecordDedupesConsecutiveReasons(t *testing.T) {
+	var r WaitRecorder
+
+	r.record("a", "1")
+	r.record("a", "1")
+	r.record("b", "2")
+	r.record("b", "3")
+
+	if len(r.Transitions) != 2 {
+		t.Fatalf("expected 2 transitions after deduping consecutive identical reasons, got %d: %+v", len(r.Transitions), r.Transitions)
+	}
+	if r.Transitions[0].Reason != "a" || r.Transitions[1].Reason != "b" {
+		t.Fatalf("unexpected transitions: %+v", r.Transitions)
+	}
+}
+
+func TestWaitRecorderFinishSetsOutcomeOnLastTransitionOnly(t *testing.T) {
+	var r WaitRecorder
+
+	r.record("a", "")
+	r.finish("timeout")
+	r.record("b", "")
+	r.finish("success")
+
+	if r.Transitions[0].Outcome != "timeout" {
+		t.Fatalf("expected first transition to keep its own outcome, untouched by the second finish call, got %q", r.Transitions[0].Outcome)
+	}
+	if r.Transitions[1].Outcome != "success" {
+		t.Fatalf("expected last transition outcome to be %q, got %q", "success", r.Transitions[1].Outcome)
+	}
+}
+
+func TestWaitRecorderNilIsANoOp(t *testing.T) {
+	var r *WaitRecorder
+	r.record("a", "")
+	r.finish("success")
+}
+
+func TestWaitRecorderFinishPendingOnlyMarksAStillPendingTransition(t *testing.T) {
+	var r WaitRecorder
+
+	r.record("a", "")
+	r.finish("success")
+	r.record("b", "")
+	// "b" never gets an outcome from eventually() itself when the wait it belongs
+	// to times out, since require.Eventually's own t.FailNow() runs before
+	// eventually can call finish. finishPending is the fallback that marks it.
+	r.finishPending("timeout")
+
+	if r.Transitions[0].Outcome != "success" {
+		t.Fatalf("expected finishPending to leave an already-concluded transition alone, got %q", r.Transitions[0].Outcome)
+	}
+	if r.Transitions[1].Outcome != "timeout" {
+		t.Fatalf("expected finishPending to mark the still-pending last transition, got %q", r.Transitions[1].Outcome)
+	}
+
+	// Calling it again once everything has an outcome must not reopen anything.
+	r.finishPending("timeout")
+	if r.Transitions[0].Outcome != "success" {
+		t.Fatalf("expected a repeated finishPending call to be a no-op on concluded transitions, got %q", r.Transitions[0].Outcome)
+	}
+}
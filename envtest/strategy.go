@@ -0,0 +1,208 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envtest
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+// WaitStrategy determines how long to wait before the next poll attempt, and whether
+// to give up entirely. attempt is 0 on the first call; elapsed is the time since the
+// wait started.
+type WaitStrategy interface {
+	Next(attempt int, elapsed time.Duration) (delay time.Duration, giveUp bool)
+}
+
+// ProgressAware is implemented by WaitStrategy implementations (such as Adaptive)
+// that adjust their next delay based on whether the most recent poll made progress,
+// e.g. because the reported reason changed since the previous poll.
+type ProgressAware interface {
+	Progress(made bool)
+}
+
+// ConstantTick polls at a fixed Tick interval and gives up once Timeout has elapsed.
+// ConstantTick{Tick: 100 * time.Millisecond, Timeout: wait.ForeverTestTimeout}
+// reproduces the fixed polling of EventuallyCondition, which does not take a
+// WaitStrategy.
+type ConstantTick struct {
+	Tick    time.Duration
+	Timeout time.Duration
+}
+
+// Next implements WaitStrategy.
+func (c ConstantTick) Next(attempt int, elapsed time.Duration) (time.Duration, bool) {
+	return c.Tick, elapsed >= c.Timeout
+}
+
+// ExponentialBackoff grows the delay between polls by Factor after each attempt,
+// bounded to [Min, Max], plus up to Jitter fraction of random jitter to spread out
+// waiters that started at the same time. This drastically reduces API server
+// pressure in envtest runs that spin up hundreds of parallel waits compared to a
+// fixed short tick. It gives up once Timeout has elapsed.
+type ExponentialBackoff struct {
+	Min, Max time.Duration
+	Factor   float64
+	Jitter   float64
+	Timeout  time.Duration
+}
+
+// Next implements WaitStrategy.
+func (e ExponentialBackoff) Next(attempt int, elapsed time.Duration) (time.Duration, bool) {
+	if elapsed >= e.Timeout {
+		return 0, true
+	}
+	delay := float64(e.Min) * math.Pow(e.Factor, float64(attempt))
+	if max := float64(e.Max); delay > max {
+		delay = max
+	}
+	if e.Jitter > 0 {
+		delay += delay * e.Jitter * rand.Float64()
+	}
+	return time.Duration(delay), false
+}
+
+// Adaptive speeds back up to Min after a poll that made progress (the reason
+// changed since the previous poll) and backs off towards Max, by Factor each time,
+// after one that didn't. It gives up once Timeout has elapsed. Use this when a
+// reconciler is expected to converge in bursts: stay responsive while things are
+// actively changing, ease off once they stall.
+type Adaptive struct {
+	Min, Max time.Duration
+	Factor   float64
+	Timeout  time.Duration
+
+	current time.Duration
+}
+
+// Progress implements ProgressAware.
+func (a *Adaptive) Progress(made bool) {
+	switch {
+	case made || a.current == 0:
+		a.current = a.Min
+	default:
+		a.current = time.Duration(float64(a.current) * a.Factor)
+		if a.current > a.Max {
+			a.current = a.Max
+		}
+	}
+}
+
+// Next implements WaitStrategy.
+func (a *Adaptive) Next(attempt int, elapsed time.Duration) (time.Duration, bool) {
+	if elapsed >= a.Timeout {
+		return 0, true
+	}
+	if a.current == 0 {
+		a.current = a.Min
+	}
+	return a.current, false
+}
+
+// pollWithStrategy repeatedly calls condition, sleeping according to strategy
+// between attempts, until it succeeds, strategy gives up, or ctx is done. It
+// performs the same recorder bookkeeping and 20%-elapsed logging as eventually:
+// a changed reason is only logged once ForeverTestTimeout/5 has elapsed, and never
+// repeated verbatim, so ConstantTick and the fast early attempts of
+// ExponentialBackoff/Adaptive don't spam the same line on every poll.
+func pollWithStrategy(ctx context.Context, t TestingT, condition func() (ok bool, reason string, resourceVersion string), strategy WaitStrategy, msgAndArgs ...interface{}) {
+	t.Helper()
+
+	recorder := recorderFor(t)
+	progressAware, isProgressAware := strategy.(ProgressAware)
+	start := time.Now()
+	var last, lastLogged string
+
+	for attempt := 0; ; attempt++ {
+		ok, reason, resourceVersion := condition()
+		recorder.record(reason, resourceVersion)
+
+		progressed := reason != last
+		if progressed {
+			last = reason
+		}
+		if isProgressAware {
+			progressAware.Progress(progressed)
+		}
+
+		if ok {
+			recorder.finish("success")
+			return
+		}
+
+		elapsed := time.Since(start)
+		if elapsed > wait.ForeverTestTimeout/5 && reason != "" && reason != lastLogged {
+			lastLogged = reason
+			t.Logf("Waiting for condition, but got: %s", reason)
+		}
+
+		delay, giveUp := strategy.Next(attempt, elapsed)
+		if giveUp || ctx.Err() != nil {
+			recorder.finish("timeout")
+			require.Fail(t, fmt.Sprintf("condition was not met: %s", reason), msgAndArgs...)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			recorder.finish("timeout")
+			require.Fail(t, fmt.Sprintf("condition was not met: %s", reason), msgAndArgs...)
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+// EventuallyConditionWithStrategy behaves like EventuallyCondition, but polls
+// according to strategy instead of the fixed 100ms tick, e.g. ExponentialBackoff to
+// ease API server pressure when envtest runs hundreds of waits in parallel.
+func EventuallyConditionWithStrategy(t TestingT, getter func() (conditions.Getter, error), matcher Matcher, strategy WaitStrategy, msgAndArgs ...interface{}) {
+	t.Helper()
+	pollWithStrategy(context.Background(), t, func() (bool, string, string) {
+		return matchCondition(t, getter, matcher)
+	}, strategy, msgAndArgs...)
+}
+
+// EventuallyContext behaves like Eventually, but instead of after a fixed waitFor it
+// gives up when ctx is done or when strategy gives up, whichever comes first, so a
+// parent test can bound the total time spent across many sub-waits and enforce an
+// end-to-end SLO for a complex multicluster scenario.
+func EventuallyContext(ctx context.Context, t TestingT, condition func() (success bool, reason string), strategy WaitStrategy, msgAndArgs ...interface{}) {
+	t.Helper()
+	pollWithStrategy(ctx, t, func() (bool, string, string) {
+		ok, reason := condition()
+		return ok, reason, ""
+	}, strategy, msgAndArgs...)
+}
+
+// EventuallyConditionContext combines EventuallyConditionWithStrategy and
+// EventuallyContext: it polls according to strategy and gives up when ctx is done.
+func EventuallyConditionContext(ctx context.Context, t TestingT, getter func() (conditions.Getter, error), matcher Matcher, strategy WaitStrategy, msgAndArgs ...interface{}) {
+	t.Helper()
+	pollWithStrategy(ctx, t, func() (bool, string, string) {
+		return matchCondition(t, getter, matcher)
+	}, strategy, msgAndArgs...)
+}
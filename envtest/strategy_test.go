@@ -0,0 +1,97 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envtest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConstantTickNext(t *testing.T) {
+	s := ConstantTick{Tick: 10 * time.Millisecond, Timeout: time.Second}
+
+	if delay, giveUp := s.Next(0, 0); delay != 10*time.Millisecond || giveUp {
+		t.Fatalf("expected (10ms, false), got (%v, %v)", delay, giveUp)
+	}
+	if _, giveUp := s.Next(5, time.Second); !giveUp {
+		t.Fatalf("expected giveUp once elapsed reaches Timeout")
+	}
+}
+
+func TestExponentialBackoffNext(t *testing.T) {
+	s := ExponentialBackoff{Min: 10 * time.Millisecond, Max: 100 * time.Millisecond, Factor: 2, Timeout: time.Second}
+
+	if delay, giveUp := s.Next(0, 0); giveUp || delay != 10*time.Millisecond {
+		t.Fatalf("expected (10ms, false) on the first attempt, got (%v, %v)", delay, giveUp)
+	}
+	if delay, giveUp := s.Next(1, 10*time.Millisecond); giveUp || delay != 20*time.Millisecond {
+		t.Fatalf("expected (20ms, false) on the second attempt, got (%v, %v)", delay, giveUp)
+	}
+	if delay, giveUp := s.Next(10, 100*time.Millisecond); giveUp || delay != 100*time.Millisecond {
+		t.Fatalf("expected the delay to be capped at Max (100ms), got (%v, %v)", delay, giveUp)
+	}
+	if _, giveUp := s.Next(0, time.Second); !giveUp {
+		t.Fatalf("expected giveUp once elapsed reaches Timeout")
+	}
+}
+
+func TestExponentialBackoffNextJitterStaysWithinBounds(t *testing.T) {
+	s := ExponentialBackoff{Min: 10 * time.Millisecond, Max: 10 * time.Millisecond, Factor: 1, Jitter: 0.5, Timeout: time.Second}
+
+	for i := 0; i < 20; i++ {
+		delay, giveUp := s.Next(0, 0)
+		if giveUp {
+			t.Fatalf("did not expect giveUp")
+		}
+		if delay < 10*time.Millisecond || delay > 15*time.Millisecond {
+			t.Fatalf("expected delay within [10ms, 15ms] with a 50%% jitter on a 10ms base, got %v", delay)
+		}
+	}
+}
+
+func TestAdaptiveNext(t *testing.T) {
+	s := &Adaptive{Min: 10 * time.Millisecond, Max: 80 * time.Millisecond, Factor: 2, Timeout: time.Second}
+
+	if delay, giveUp := s.Next(0, 0); giveUp || delay != 10*time.Millisecond {
+		t.Fatalf("expected Adaptive to start at Min, got (%v, %v)", delay, giveUp)
+	}
+
+	s.Progress(false)
+	if delay, _ := s.Next(1, 0); delay != 20*time.Millisecond {
+		t.Fatalf("expected backoff to double to 20ms after no progress, got %v", delay)
+	}
+
+	s.Progress(false)
+	if delay, _ := s.Next(2, 0); delay != 40*time.Millisecond {
+		t.Fatalf("expected backoff to double to 40ms after no progress, got %v", delay)
+	}
+
+	s.Progress(false)
+	s.Progress(false) // would be 160ms uncapped, must clamp to Max
+	if delay, _ := s.Next(3, 0); delay != 80*time.Millisecond {
+		t.Fatalf("expected backoff to be capped at Max (80ms), got %v", delay)
+	}
+
+	s.Progress(true)
+	if delay, _ := s.Next(4, 0); delay != 10*time.Millisecond {
+		t.Fatalf("expected progress to reset the delay back to Min, got %v", delay)
+	}
+
+	if _, giveUp := s.Next(0, time.Second); !giveUp {
+		t.Fatalf("expected giveUp once elapsed reaches Timeout")
+	}
+}
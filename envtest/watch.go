@@ -0,0 +1,282 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envtest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// watchTimeout bounds how long EventuallyWatch and mcEventuallyWatch will wait
+// overall. It's a var rather than using wait.ForeverTestTimeout directly so that
+// tests can shorten it to exercise the failure path without an actual 30s wait.
+var watchTimeout = wait.ForeverTestTimeout
+
+// EventuallyWatch asserts that predicate eventually holds for some object observed
+// on a Watch against list, established through cl. Unlike EventuallyCondition, which
+// polls on a fixed 100ms tick, this reacts to ADDED/MODIFIED events as they arrive,
+// which removes both the poll latency and the "getter returns a stale cache" failure
+// mode that can plague e2e suites waiting on many objects across many logical
+// clusters. Like a standard reflector, it lists before it watches: a Watch with no
+// resourceVersion only reports future changes, so without the list a predicate that
+// is already true when EventuallyWatch is called would never be observed and the
+// call would hang until the timeout. If the watch closes before the predicate holds,
+// EventuallyWatch transparently re-lists and re-establishes it rather than failing
+// outright. As with Eventually, the last-seen predicate reason is logged once 20% of
+// the wait time has elapsed.
+func EventuallyWatch[T client.Object](t TestingT, cl client.WithWatch, list client.ObjectList, predicate func(T) (bool, string), opts ...client.ListOption) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), watchTimeout)
+	defer cancel()
+
+	start := time.Now()
+	var last string
+	logReason := func(reason string) {
+		if time.Since(start) > watchTimeout/5 && reason != "" && reason != last {
+			last = reason
+			t.Logf("Waiting for condition, but got: %s", reason)
+		}
+	}
+
+	for {
+		done, reason, err := listMatches(ctx, cl, list, predicate, opts...)
+		require.NoError(t, err, "Error listing objects")
+		if done {
+			return
+		}
+		logReason(reason)
+
+		// Watch from the list's resourceVersion rather than "most recent": without
+		// it, an update landing between the list and the watch being established
+		// would be missed entirely, and, absent any later event, EventuallyWatch
+		// would hang until the timeout despite the object already satisfying the
+		// predicate.
+		w, err := cl.Watch(ctx, list, withResourceVersion(list.GetResourceVersion(), opts...)...)
+		require.NoError(t, err, "Error establishing watch")
+
+		done, reason = drainWatch(ctx, w, predicate, logReason)
+		w.Stop()
+		if done {
+			return
+		}
+		if ctx.Err() != nil {
+			require.Fail(t, fmt.Sprintf("timed out waiting for condition: %s", reason))
+			return
+		}
+		// The watch's result channel closed (e.g. the apiserver connection was
+		// recycled) without the predicate ever holding: re-list and watch again.
+	}
+}
+
+// listMatches lists objects matching opts and reports whether predicate already
+// holds for one of them, without needing a watch event to observe it.
+func listMatches[T client.Object](ctx context.Context, cl client.Reader, list client.ObjectList, predicate func(T) (bool, string), opts ...client.ListOption) (bool, string, error) {
+	if err := cl.List(ctx, list, opts...); err != nil {
+		return false, "", err
+	}
+	items, err := apimeta.ExtractList(list)
+	if err != nil {
+		return false, "", err
+	}
+	var lastReason string
+	for _, item := range items {
+		obj, ok := item.(T)
+		if !ok {
+			continue
+		}
+		done, reason := predicate(obj)
+		lastReason = reason
+		if done {
+			return true, reason, nil
+		}
+	}
+	return false, lastReason, nil
+}
+
+// withResourceVersion appends a ListOption requesting resourceVersion to opts, so
+// that a subsequent Watch picks up exactly where a preceding List left off instead
+// of starting from "most recent" and risking a missed update in between. It is a
+// no-op if resourceVersion is empty.
+func withResourceVersion(resourceVersion string, opts ...client.ListOption) []client.ListOption {
+	if resourceVersion == "" {
+		return opts
+	}
+	return append(append([]client.ListOption{}, opts...), &client.ListOptions{Raw: &metav1.ListOptions{ResourceVersion: resourceVersion}})
+}
+
+// drainWatch consumes events from w until predicate holds for one of them, ctx is
+// done, or the result channel closes.
+func drainWatch[T client.Object](ctx context.Context, w watch.Interface, predicate func(T) (bool, string), logReason func(string)) (bool, string) {
+	var lastReason string
+	for {
+		select {
+		case <-ctx.Done():
+			return false, lastReason
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return false, lastReason
+			}
+			if event.Type != watch.Added && event.Type != watch.Modified {
+				continue
+			}
+			obj, ok := event.Object.(T)
+			if !ok {
+				continue
+			}
+			done, reason := predicate(obj)
+			lastReason = reason
+			logReason(reason)
+			if done {
+				return true, reason
+			}
+		}
+	}
+}
+
+// WatchScope selects which of the clusters fanned out over by mcEventuallyWatch must
+// observe the predicate holding before it succeeds.
+type WatchScope int
+
+const (
+	// WatchAny succeeds as soon as the predicate holds on any one cluster.
+	WatchAny WatchScope = iota
+	// WatchAll requires the predicate to hold on every cluster before succeeding.
+	WatchAll
+)
+
+// mcEventuallyWatch fans EventuallyWatch out across clusterNames, using getClient to
+// obtain a watch-capable client for each one (typically backed by the per-cluster
+// client.Cluster a multicluster.Provider hands out for a known logical cluster), and
+// succeeds according to scope: as soon as any one cluster satisfies predicate
+// (WatchAny), or only once every cluster does (WatchAll).
+func mcEventuallyWatch[T client.Object](t TestingT, clusterNames []string, getClient func(clusterName string) (client.WithWatch, error), list client.ObjectList, predicate func(T) (bool, string), scope WatchScope, opts ...client.ListOption) {
+	t.Helper()
+	require.NotEmpty(t, clusterNames, "no clusters to watch")
+
+	ctx, cancel := context.WithTimeout(context.Background(), watchTimeout)
+	defer cancel()
+
+	done := make(chan string, len(clusterNames))
+	for _, clusterName := range clusterNames {
+		clusterName := clusterName
+		go func() {
+			cl, err := getClient(clusterName)
+			if err != nil {
+				t.Logf("Error getting client for cluster %s: %v", clusterName, err)
+				<-ctx.Done()
+				done <- ""
+				return
+			}
+
+			// Each cluster lists into its own copy, so concurrent Lists don't
+			// overwrite each other's items and resourceVersion.
+			list := list.DeepCopyObject().(client.ObjectList)
+			for {
+				listDone, _, err := listMatches(ctx, cl, list, predicate, opts...)
+				if err != nil {
+					t.Logf("Error listing objects on cluster %s: %v", clusterName, err)
+					<-ctx.Done()
+					done <- ""
+					return
+				}
+				if listDone {
+					done <- clusterName
+					return
+				}
+
+				w, err := cl.Watch(ctx, list, withResourceVersion(list.GetResourceVersion(), opts...)...)
+				if err != nil {
+					t.Logf("Error establishing watch on cluster %s: %v", clusterName, err)
+					<-ctx.Done()
+					done <- ""
+					return
+				}
+				ok, _ := drainWatch(ctx, w, predicate, func(string) {})
+				w.Stop()
+				if ok {
+					done <- clusterName
+					return
+				}
+				if ctx.Err() != nil {
+					done <- ""
+					return
+				}
+				// The watch closed without the predicate holding: re-list and
+				// watch again, as EventuallyWatch does.
+			}
+		}()
+	}
+
+	satisfied := collectWatchResults(ctx, done, len(clusterNames), scope)
+	if !watchScopeSatisfied(scope, len(satisfied), len(clusterNames)) {
+		require.Fail(t, fmt.Sprintf("timed out waiting for condition on clusters %v, satisfied so far: %v", clusterNames, satisfied))
+	}
+}
+
+// collectWatchResults drains done until every cluster has reported in or ctx is
+// done, returning the set of clusters observed satisfied so far. Once ctx expires,
+// every still-running per-cluster goroutine becomes ready to send "" into the
+// buffered done channel at the same moment this function's own ctx.Done() case
+// becomes ready, and select picks among ready cases at random — so which case fires
+// here is not reliable. The caller must therefore judge success with
+// watchScopeSatisfied against the returned set afterwards, regardless of whether
+// this loop ran out of clusterNames or exited via ctx.Done().
+func collectWatchResults(ctx context.Context, done <-chan string, total int, scope WatchScope) map[string]bool {
+	satisfied := make(map[string]bool, total)
+	for i := 0; i < total; i++ {
+		select {
+		case clusterName := <-done:
+			if clusterName == "" {
+				continue
+			}
+			satisfied[clusterName] = true
+			if scope == WatchAny {
+				return satisfied
+			}
+			if len(satisfied) == total {
+				return satisfied
+			}
+		case <-ctx.Done():
+			return satisfied
+		}
+	}
+	return satisfied
+}
+
+// watchScopeSatisfied reports whether scope's success condition holds given how many
+// of total clusters ended up satisfied.
+func watchScopeSatisfied(scope WatchScope, satisfiedCount, total int) bool {
+	switch scope {
+	case WatchAny:
+		return satisfiedCount > 0
+	case WatchAll:
+		return satisfiedCount == total
+	default:
+		return false
+	}
+}
@@ -0,0 +1,263 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envtest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeTestingT is a minimal TestingT that records failures instead of stopping the
+// goroutine it's called from, so that mcEventuallyWatch's outcome can be asserted on
+// directly rather than via a real (sub)test's pass/fail status.
+type fakeTestingT struct {
+	mu     sync.Mutex
+	failed bool
+	logs   []string
+}
+
+func (f *fakeTestingT) Helper() {}
+
+func (f *fakeTestingT) Logf(format string, args ...interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.logs = append(f.logs, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeTestingT) Errorf(format string, args ...interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failed = true
+	f.logs = append(f.logs, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeTestingT) FailNow() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failed = true
+}
+
+func (f *fakeTestingT) Failed() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.failed
+}
+
+func newFakeWatchClient(t *testing.T, objs ...client.Object) client.WithWatch {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func configMap(name string, data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Data:       data,
+	}
+}
+
+func readyPredicate(cm *corev1.ConfigMap) (bool, string) {
+	return cm.Data["ready"] == "true", fmt.Sprintf("ready=%s", cm.Data["ready"])
+}
+
+func TestListMatchesFindsAnAlreadySatisfyingObject(t *testing.T) {
+	cl := newFakeWatchClient(t, configMap("cm", map[string]string{"ready": "true"}))
+
+	done, reason, err := listMatches(context.Background(), cl, &corev1.ConfigMapList{}, readyPredicate, client.InNamespace("default"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !done {
+		t.Fatalf("expected listMatches to observe the already-satisfying object, last reason: %s", reason)
+	}
+}
+
+func TestListMatchesReportsLastReasonWhenNothingMatches(t *testing.T) {
+	cl := newFakeWatchClient(t, configMap("cm", map[string]string{"ready": "false"}))
+
+	done, reason, err := listMatches(context.Background(), cl, &corev1.ConfigMapList{}, readyPredicate, client.InNamespace("default"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if done {
+		t.Fatalf("did not expect a match")
+	}
+	if reason != "ready=false" {
+		t.Fatalf("unexpected reason: %s", reason)
+	}
+}
+
+func TestDrainWatchReturnsOnceAnEventSatisfiesThePredicate(t *testing.T) {
+	w := watch.NewFake()
+	defer w.Stop()
+
+	go func() {
+		w.Add(configMap("cm", map[string]string{"ready": "false"}))
+		w.Modify(configMap("cm", map[string]string{"ready": "true"}))
+	}()
+
+	done, reason := drainWatch(context.Background(), w, readyPredicate, func(string) {})
+	if !done {
+		t.Fatalf("expected drainWatch to observe the satisfying event, last reason: %s", reason)
+	}
+}
+
+func TestDrainWatchStopsWhenContextIsDone(t *testing.T) {
+	w := watch.NewFake()
+	defer w.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	done, _ := drainWatch(ctx, w, func(*corev1.ConfigMap) (bool, string) { return false, "" }, func(string) {})
+	if done {
+		t.Fatalf("did not expect drainWatch to report done once ctx is already cancelled")
+	}
+}
+
+func TestWatchScopeSatisfied(t *testing.T) {
+	cases := []struct {
+		scope     WatchScope
+		satisfied int
+		total     int
+		want      bool
+	}{
+		{WatchAny, 0, 3, false},
+		{WatchAny, 1, 3, true},
+		{WatchAll, 2, 3, false},
+		{WatchAll, 3, 3, true},
+	}
+	for _, c := range cases {
+		if got := watchScopeSatisfied(c.scope, c.satisfied, c.total); got != c.want {
+			t.Fatalf("watchScopeSatisfied(%v, %d, %d) = %v, want %v", c.scope, c.satisfied, c.total, got, c.want)
+		}
+	}
+}
+
+func TestCollectWatchResultsStopsEarlyOnWatchAnySuccess(t *testing.T) {
+	done := make(chan string, 1)
+	done <- "cluster-a"
+
+	satisfied := collectWatchResults(context.Background(), done, 3, WatchAny)
+	if !satisfied["cluster-a"] {
+		t.Fatalf("expected cluster-a to be recorded satisfied, got %v", satisfied)
+	}
+}
+
+// TestCollectWatchResultsRaceBetweenDoneAndContextStillYieldsCorrectFailure
+// reproduces the race described in review: a cluster's goroutine sends "" (it
+// errored or timed out) into the buffered channel at the same moment ctx expires,
+// so select may pick either case. Previously, WatchAny only failed via the
+// ctx.Done() case of the outer select, so picking the done-channel case instead let
+// the function return as if it had succeeded. watchScopeSatisfied must report
+// failure here no matter which path collectWatchResults took.
+func TestCollectWatchResultsRaceBetweenDoneAndContextStillYieldsCorrectFailure(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	done := make(chan string, 1)
+	done <- ""
+
+	satisfied := collectWatchResults(ctx, done, 1, WatchAny)
+	if watchScopeSatisfied(WatchAny, len(satisfied), 1) {
+		t.Fatalf("expected watchScopeSatisfied to report failure when no cluster was ever satisfied, got satisfied=%v", satisfied)
+	}
+}
+
+func TestCollectWatchResultsContextDoneWithNothingSatisfied(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	done := make(chan string)
+
+	satisfied := collectWatchResults(ctx, done, 1, WatchAny)
+	if len(satisfied) != 0 {
+		t.Fatalf("expected no clusters satisfied, got %v", satisfied)
+	}
+}
+
+// withShortWatchTimeout shrinks watchTimeout for the duration of a test, so that
+// mcEventuallyWatch's otherwise hardcoded 30s internal deadline can be exercised
+// quickly and deterministically.
+func withShortWatchTimeout(t *testing.T, d time.Duration) {
+	t.Helper()
+	original := watchTimeout
+	watchTimeout = d
+	t.Cleanup(func() { watchTimeout = original })
+}
+
+func TestMcEventuallyWatchFailsUnderWatchAnyWhenTheOnlyClusterErrors(t *testing.T) {
+	withShortWatchTimeout(t, 50*time.Millisecond)
+
+	ft := &fakeTestingT{}
+	mcEventuallyWatch[*corev1.ConfigMap](ft, []string{"unreachable"}, func(clusterName string) (client.WithWatch, error) {
+		return nil, fmt.Errorf("cluster %s is unreachable", clusterName)
+	}, &corev1.ConfigMapList{}, readyPredicate, WatchAny)
+
+	if !ft.Failed() {
+		t.Fatalf("expected mcEventuallyWatch to fail when WatchAny's only cluster errors, logs: %v", ft.logs)
+	}
+}
+
+func TestMcEventuallyWatchFailsUnderWatchAllWhenOneClusterErrors(t *testing.T) {
+	withShortWatchTimeout(t, 50*time.Millisecond)
+
+	good := newFakeWatchClient(t, configMap("cm", map[string]string{"ready": "true"}))
+	ft := &fakeTestingT{}
+	mcEventuallyWatch[*corev1.ConfigMap](ft, []string{"ok", "unreachable"}, func(clusterName string) (client.WithWatch, error) {
+		if clusterName == "unreachable" {
+			return nil, fmt.Errorf("cluster %s is unreachable", clusterName)
+		}
+		return good, nil
+	}, &corev1.ConfigMapList{}, readyPredicate, WatchAll, client.InNamespace("default"))
+
+	if !ft.Failed() {
+		t.Fatalf("expected mcEventuallyWatch to fail under WatchAll when one of two clusters errors, logs: %v", ft.logs)
+	}
+}
+
+func TestMcEventuallyWatchSucceedsUnderWatchAnyAsSoonAsOneClusterMatches(t *testing.T) {
+	withShortWatchTimeout(t, time.Second)
+
+	good := newFakeWatchClient(t, configMap("cm", map[string]string{"ready": "true"}))
+	ft := &fakeTestingT{}
+	mcEventuallyWatch[*corev1.ConfigMap](ft, []string{"a", "b"}, func(clusterName string) (client.WithWatch, error) {
+		if clusterName == "a" {
+			return good, nil
+		}
+		// cluster "b" never satisfies the predicate, but WatchAny shouldn't need it to.
+		return newFakeWatchClient(t, configMap("cm", map[string]string{"ready": "false"})), nil
+	}, &corev1.ConfigMapList{}, readyPredicate, WatchAny, client.InNamespace("default"))
+
+	if ft.Failed() {
+		t.Fatalf("expected mcEventuallyWatch to succeed once one cluster matches under WatchAny, logs: %v", ft.logs)
+	}
+}